@@ -3,15 +3,41 @@ package framework
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
 )
 
 var (
 	alreadyRegisteredError = errors.New("component a already has a dependency on component b")
-	dependencyCycleError   = errors.New("Dependency cycle. component b already depends on component a.")
 )
 
+// CycleError is returned by RegisterDependentComponents when adding a
+// dependency would introduce a cycle anywhere in the dependency graph, not
+// just a direct A<->B cycle. Path describes the chain of dependencies that
+// would form the cycle, starting and ending at the component the cycle was
+// detected for.
+type CycleError struct {
+	Path []Component
+}
+
+func (e *CycleError) Error() string {
+	names := make([]string, 0, len(e.Path))
+	for _, c := range e.Path {
+		names = append(names, fmt.Sprintf("%T", c))
+	}
+	return fmt.Sprintf("dependency cycle detected: %s", strings.Join(names, " -> "))
+}
+
 // Component defines the functionality that is required to start and stop a component when a service is started
 type Component interface {
 	// Will be called to start the component
@@ -28,122 +54,686 @@ type Component interface {
 
 // Service contains all of the components that you wish to have running
 type Service struct {
-	components          []Component
-	dependantComponents map[Component][]Component
-	startedComponents   map[Component]bool
+	*BaseComponent
+
+	components []Component
+
+	// dependencies maps a component to the set of components that it depends on.
+	dependencies map[Component]map[Component]struct{}
+	// dependents is the reverse of dependencies: it maps a component to the set
+	// of components that depend on it. It lets us walk the graph forwards when
+	// resolving the topological order.
+	dependents map[Component]map[Component]struct{}
 
-	closingStack *Stack
+	maxConcurrency       int
+	startTimeout         time.Duration
+	stopTimeout          time.Duration
+	componentStopTimeout time.Duration
+	forceKillSlowStops   bool
+	readyPollInterval    time.Duration
+	readyTimeout         time.Duration
+
+	// tracer is nil unless WithTracer was used, in which case Start/Stop and each
+	// component's Start/Stop are wrapped in spans.
+	tracer trace.Tracer
+
+	// mu guards components, dependencies, dependents, started and startedLayers: all of
+	// these can be mutated at runtime by AddComponent/RemoveComponent while DependencyGraph
+	// and HealthEndpoint's handlers are reading them concurrently.
+	mu sync.RWMutex
+	// started records every component that has successfully started.
+	started map[Component]bool
+	// startedLayers records, in the order they were started, the components of each
+	// dependency layer that actually started successfully. Stop walks it in reverse so
+	// that dependents are always stopped before the components they depend on.
+	startedLayers [][]Component
+}
+
+// Option configures optional behaviour of a Service, set via NewService.
+type Option func(*Service)
+
+// WithMaxConcurrency caps the number of components within a single dependency layer that
+// Start/Stop will run concurrently. A value <= 0 (the default) means no cap.
+func WithMaxConcurrency(n int) Option {
+	return func(s *Service) {
+		s.maxConcurrency = n
+	}
+}
+
+// WithStartTimeout bounds the overall time Start is allowed to take. A value <= 0 (the
+// default) means no timeout is applied.
+func WithStartTimeout(d time.Duration) Option {
+	return func(s *Service) {
+		s.startTimeout = d
+	}
+}
+
+// WithStopTimeout bounds the overall time Stop is allowed to take. A value <= 0 (the
+// default) means no timeout is applied.
+func WithStopTimeout(d time.Duration) Option {
+	return func(s *Service) {
+		s.stopTimeout = d
+	}
+}
+
+// WithReadyPollInterval sets how often Start polls a HealthChecker component's Ready method
+// while waiting for it to become ready. Defaults to defaultReadyPollInterval.
+func WithReadyPollInterval(d time.Duration) Option {
+	return func(s *Service) {
+		s.readyPollInterval = d
+	}
+}
+
+// WithReadyTimeout bounds how long Start will wait for a single component to become ready
+// before giving up and failing. Defaults to defaultReadyTimeout.
+func WithReadyTimeout(d time.Duration) Option {
+	return func(s *Service) {
+		s.readyTimeout = d
+	}
 }
 
+// WithComponentStopTimeout bounds how long each individual component is given to Stop. A
+// value <= 0 (the default) means no per-component timeout is applied.
+func WithComponentStopTimeout(d time.Duration) Option {
+	return func(s *Service) {
+		s.componentStopTimeout = d
+	}
+}
+
+// WithForceKillSlowStops makes Stop abandon, rather than wait on, any component whose Stop
+// exceeds its WithComponentStopTimeout budget: the component's context is cancelled and
+// Stop moves on to the rest of the layer, logging that the component's Stop goroutine was
+// left running. Has no effect unless WithComponentStopTimeout is also set.
+func WithForceKillSlowStops() Option {
+	return func(s *Service) {
+		s.forceKillSlowStops = true
+	}
+}
+
+// WithLogger sets the structured logger used for Service's own log output, and is also
+// propagated to every component that embeds a BaseComponent. Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Service) {
+		s.SetLogger(logger)
+	}
+}
+
+// WithTracer enables OpenTelemetry tracing: each call to Start/Stop, and each individual
+// component's Start/Stop, is wrapped in a span from tp, parented to a root "service.Start"
+// or "service.Stop" span. Tracing is disabled (the default) unless this is set.
+func WithTracer(tp trace.TracerProvider) Option {
+	return func(s *Service) {
+		s.tracer = tp.Tracer("github.com/will-andrews-form3/framework-poc")
+	}
+}
+
+const (
+	defaultReadyPollInterval = 100 * time.Millisecond
+	defaultReadyTimeout      = 30 * time.Second
+)
+
 // NewService creates a new service
-func NewService(components []Component) *Service {
+func NewService(components []Component, opts ...Option) *Service {
+	s := &Service{
+		BaseComponent:     NewBaseComponent(),
+		components:        components,
+		dependencies:      make(map[Component]map[Component]struct{}),
+		dependents:        make(map[Component]map[Component]struct{}),
+		started:           make(map[Component]bool),
+		readyPollInterval: defaultReadyPollInterval,
+		readyTimeout:      defaultReadyTimeout,
+	}
 
-	return &Service{
-		components:          components,
-		dependantComponents: make(map[Component][]Component),
-		startedComponents:   make(map[Component]bool),
-		closingStack:        NewStack(),
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	for _, component := range components {
+		if l, ok := component.(interface{ SetLogger(*slog.Logger) }); ok {
+			l.SetLogger(s.Logger())
+		}
 	}
+
+	return s
 }
 
 // RegisterDependentComponents will register that component a depends on component b
 func (s *Service) RegisterDependentComponents(a, b Component) error {
-	// check that b doesn't depend on a to avoid cyclic dependencies
-	dependencies, ok := s.dependantComponents[b]
-	if ok {
-		for _, dep := range dependencies {
-			if dep == a {
-				return dependencyCycleError
-			}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.registerDependency(a, b)
+}
+
+// registerDependency records that a depends on b, rejecting the edge if it's already been
+// registered or if it would introduce a cycle of any length. It doesn't take s.mu itself -
+// callers (RegisterDependentComponents, AddComponent) must already hold it - so both go
+// through the same cycle check under a single critical section.
+func (s *Service) registerDependency(a, b Component) error {
+	// check to see if the dependency has already been set up
+	if _, ok := s.dependencies[a][b]; ok {
+		return alreadyRegisteredError
+	}
+
+	// check that b doesn't already (transitively) depend on a, which would make this new edge
+	// part of a cycle of any length
+	if path := findPath(s.dependencies, b, a); path != nil {
+		return &CycleError{Path: append([]Component{a}, path...)}
+	}
+
+	if s.dependencies[a] == nil {
+		s.dependencies[a] = make(map[Component]struct{})
+	}
+	s.dependencies[a][b] = struct{}{}
+
+	if s.dependents[b] == nil {
+		s.dependents[b] = make(map[Component]struct{})
+	}
+	s.dependents[b][a] = struct{}{}
+
+	return nil
+}
+
+// findPath performs a DFS over graph looking for a path from `from` to `to`, following
+// dependency edges. It returns the path (inclusive of both ends) if one exists, or nil.
+func findPath(graph map[Component]map[Component]struct{}, from, to Component) []Component {
+	if from == to {
+		return []Component{from}
+	}
+
+	for next := range graph[from] {
+		if path := findPath(graph, next, to); path != nil {
+			return append([]Component{from}, path...)
 		}
 	}
 
-	// check to see if the dependency has already been set up
-	existingDependencies, ok := s.dependantComponents[a]
-	if ok {
-		for _, dependency := range existingDependencies {
-			if dependency == b {
-				return alreadyRegisteredError
+	return nil
+}
+
+// DependencyGraph resolves the components into topologically-ordered layers: components in
+// layer N depend only on components in layers < N, and every component in a given layer could,
+// in principle, be started concurrently with the rest of that layer. It's exposed so tests and
+// operators can inspect the plan that Start will use.
+func (s *Service) DependencyGraph() [][]Component {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	remaining := make(map[Component]int, len(s.components))
+	for _, c := range s.components {
+		remaining[c] = len(s.dependencies[c])
+	}
+
+	placed := make(map[Component]bool, len(s.components))
+
+	var layers [][]Component
+	for len(placed) < len(s.components) {
+		var layer []Component
+		for _, c := range s.components {
+			if !placed[c] && remaining[c] == 0 {
+				layer = append(layer, c)
+			}
+		}
+
+		// RegisterDependentComponents rejects anything that would introduce a cycle, so a cycle
+		// can't get here. A component that depends on one missing from s.components does,
+		// though: it never runs out of remaining dependencies, so it and its dependents are
+		// left out of the layers. Bail out rather than loop forever.
+		if len(layer) == 0 {
+			break
+		}
+
+		for _, c := range layer {
+			placed[c] = true
+			for dependent := range s.dependents[c] {
+				remaining[dependent]--
 			}
 		}
+
+		layers = append(layers, layer)
 	}
 
-	s.dependantComponents[a] = append(s.dependantComponents[a], b)
+	return layers
+}
+
+// snapshotGraph returns a point-in-time copy of the components list and the dependency graph,
+// safe to read without holding s.mu. HealthEndpoint uses this so that handling a request
+// doesn't hold the lock across calls to HealthChecker.Healthy/Ready, which may block on I/O,
+// while AddComponent/RemoveComponent are free to keep mutating the real thing concurrently.
+func (s *Service) snapshotGraph() ([]Component, map[Component]map[Component]struct{}) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	return nil
+	components := append([]Component(nil), s.components...)
+
+	dependencies := make(map[Component]map[Component]struct{}, len(s.dependencies))
+	for c, deps := range s.dependencies {
+		depsCopy := make(map[Component]struct{}, len(deps))
+		for d := range deps {
+			depsCopy[d] = struct{}{}
+		}
+		dependencies[c] = depsCopy
+	}
+
+	return components, dependencies
 }
 
-// Start will start the components that have been added. It will ensure that components that are dependencies of
-// other components are started before the components that depend on them. If any component fails to start, an error
-// will be returned without continuing to start the rest of the components.
+// Start will start the components that have been added. Components in the same dependency
+// layer (i.e. with no dependency relationship between them) are started concurrently; a
+// layer only begins once every component in the layer below it has started. If any
+// component fails to start, the rest of its layer is given the chance to return before Start
+// returns the error, but no further layers are started. Components that did start
+// successfully - including the ones that raced ahead of the failure - remain registered so
+// that Stop can tear them down. Start returns ErrAlreadyStarted if it has already been
+// called on this Service.
 func (s *Service) Start(ctx context.Context) error {
-	for _, component := range s.components {
-		err := s.startComponent(ctx, component)
-		if err != nil {
-			return errors.Wrap(err, "failed to start component")
+	if err := s.MarkStarting(); err != nil {
+		return err
+	}
+
+	var err error
+	ctx, endSpan := s.startSpan(ctx, "service.Start")
+	defer func() { endSpan(err) }()
+
+	if s.startTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.startTimeout)
+		defer cancel()
+	}
+
+	for _, layer := range s.DependencyGraph() {
+		g, gctx := errgroup.WithContext(ctx)
+		if s.maxConcurrency > 0 {
+			g.SetLimit(s.maxConcurrency)
+		}
+
+		for _, component := range layer {
+			component := component
+			g.Go(func() error {
+				return s.startComponent(gctx, component)
+			})
+		}
+
+		startErr := g.Wait()
+		s.recordStartedLayer(layer)
+
+		if startErr != nil {
+			err = errors.Wrap(startErr, "failed to start component")
+			s.MarkTerminated(err)
+			return err
 		}
 	}
 
+	s.MarkRunning()
 	return nil
 }
 
-func (s *Service) Stop(ctx context.Context) {
-	s.closingStack.Close(ctx)
-}
+// Stop tears down every component that Start managed to start, one dependency layer at a
+// time, starting with the layer that was started last. Components within a layer are
+// stopped concurrently since none of them depend on each other. Stop is idempotent: if a
+// shutdown is already in progress, subsequent calls just wait for it to finish and return
+// the same result. Errors returned by individual components' Stop are collected and
+// returned together as a *multierror.Error rather than being swallowed.
+func (s *Service) Stop(ctx context.Context) error {
+	if !s.MarkStopping() {
+		s.Wait()
+		// A failed Start has already terminated the service, but leaves whatever it did
+		// start in startedLayers for Stop to tear down.
+		s.mu.RLock()
+		leftover := len(s.startedLayers) > 0
+		s.mu.RUnlock()
+		if !leftover {
+			return s.Err()
+		}
+	}
 
-func (s *Service) startComponent(ctx context.Context, component Component) error {
-	// check the component hasn't already been started due to it being a dependent component
-	if _, ok := s.startedComponents[component]; ok {
-		return nil
+	var err error
+	ctx, endSpan := s.startSpan(ctx, "service.Stop")
+	defer func() { endSpan(err) }()
+
+	if s.stopTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.stopTimeout)
+		defer cancel()
 	}
 
-	// first check for components that this component depends upon so that they can be started first
-	depComponents := s.checkForDependantComponents(component)
+	var (
+		mu     sync.Mutex
+		result *multierror.Error
+	)
 
-	if len(depComponents) > 0 {
-		for _, dependency := range depComponents {
-			err := s.startComponent(ctx, dependency)
-			if err != nil {
-				return errors.Wrap(err, "failed to start component")
-			}
+	s.mu.Lock()
+	startedLayers := s.startedLayers
+	s.startedLayers = nil
+	s.mu.Unlock()
+
+	for i := len(startedLayers) - 1; i >= 0; i-- {
+		var g errgroup.Group
+		if s.maxConcurrency > 0 {
+			g.SetLimit(s.maxConcurrency)
 		}
+
+		for _, component := range startedLayers[i] {
+			component := component
+			g.Go(func() error {
+				if err := s.stopComponent(ctx, component); err != nil {
+					mu.Lock()
+					result = multierror.Append(result, err)
+					mu.Unlock()
+				}
+				return nil
+			})
+		}
+
+		_ = g.Wait()
 	}
 
-	err := component.Start(ctx)
-	if err != nil {
-		return errors.Wrap(err, "failed to start component")
+	err = result.ErrorOrNil()
+	s.MarkTerminated(err)
+	return err
+}
+
+// Run starts every component, then blocks until ctx is cancelled or the process receives
+// SIGINT/SIGTERM, at which point it stops every component and returns any errors collected
+// along the way. The shutdown is bounded only by WithStopTimeout; without it, Run waits for
+// every component's Stop to return, however long that takes.
+func (s *Service) Run(ctx context.Context) error {
+	if err := s.Start(ctx); err != nil {
+		return err
+	}
+
+	sigCtx, stopNotifying := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stopNotifying()
+
+	<-sigCtx.Done()
+
+	return s.Stop(context.Background())
+}
+
+// startSpan starts a new span named name if WithTracer was used, parented to ctx, and
+// returns the (possibly updated) context along with a function that must be called - most
+// often via defer - to record err (which may be nil) against the span and end it. If tracing
+// is disabled, it's a no-op: ctx is returned unchanged and the returned function does nothing.
+func (s *Service) startSpan(ctx context.Context, name string) (context.Context, func(err error)) {
+	if s.tracer == nil {
+		return ctx, func(error) {}
 	}
 
-	// We need to stop components in the opposite order that they are started. This is to ensure that
-	// dependency components are shut down after components that depend on them. By adding them to the
-	// closing stack here (which closes them in reverse order of being added), we ensure that dependency
-	// components are added before the components that depend on them, thus being shut down after the
-	// components that depend on them
-	s.closingStack.Add("something", CloseFunc(func(ctx context.Context) {
-		err := component.Stop(ctx)
+	ctx, span := s.tracer.Start(ctx, name)
+	return ctx, func(err error) {
 		if err != nil {
-			fmt.Printf("failed to stop component: %s\n", err)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
 		}
-	}))
+		span.End()
+	}
+}
+
+func (s *Service) startComponent(ctx context.Context, component Component) (err error) {
+	ctx, endSpan := s.startSpan(ctx, fmt.Sprintf("component.Start %T", component))
+	defer func() { endSpan(err) }()
+
+	start := time.Now()
+	s.Logger().Info("starting component", "component", fmt.Sprintf("%T", component))
+
+	if err = component.Start(ctx); err != nil {
+		err = errors.Wrap(err, "failed to start component")
+		s.Logger().Error("component failed to start",
+			"component", fmt.Sprintf("%T", component),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"error", err)
+		return err
+	}
+
+	// A component isn't considered started - and so its dependents must not be started -
+	// until it reports itself ready, if it implements HealthChecker at all.
+	if err = s.waitUntilReady(ctx, component); err != nil {
+		s.Logger().Error("component failed to become ready",
+			"component", fmt.Sprintf("%T", component),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"error", err)
+		return err
+	}
+
+	s.mu.Lock()
+	s.started[component] = true
+	s.mu.Unlock()
+
+	s.Logger().Info("started component",
+		"component", fmt.Sprintf("%T", component),
+		"duration_ms", time.Since(start).Milliseconds())
 
-	s.startedComponents[component] = true
 	return nil
 }
 
-func (s *Service) checkForDependantComponents(componentToCheck Component) []Component {
-	components := make([]Component, 0, 0)
-	dependencies, ok := s.dependantComponents[componentToCheck]
+// stopComponent calls component.Stop, applying the configured per-component timeout. If
+// WithForceKillSlowStops is set and the component doesn't return within that budget, its
+// context is cancelled and stopComponent returns without waiting any further, leaving the
+// component's Stop goroutine to finish (or not) on its own.
+func (s *Service) stopComponent(ctx context.Context, component Component) (err error) {
+	ctx, endSpan := s.startSpan(ctx, fmt.Sprintf("component.Stop %T", component))
+	defer func() { endSpan(err) }()
+
+	start := time.Now()
+	s.Logger().Info("stopping component", "component", fmt.Sprintf("%T", component))
+
+	defer func() {
+		if err != nil {
+			s.Logger().Error("component failed to stop",
+				"component", fmt.Sprintf("%T", component),
+				"duration_ms", time.Since(start).Milliseconds(),
+				"error", err)
+			return
+		}
+		s.Logger().Info("stopped component",
+			"component", fmt.Sprintf("%T", component),
+			"duration_ms", time.Since(start).Milliseconds())
+	}()
+
+	if s.componentStopTimeout <= 0 {
+		err = errors.Wrap(component.Stop(ctx), "failed to stop component")
+		return err
+	}
+
+	stopCtx, cancel := context.WithTimeout(ctx, s.componentStopTimeout)
+	defer cancel()
+
+	if !s.forceKillSlowStops {
+		err = errors.Wrap(component.Stop(stopCtx), "failed to stop component")
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- component.Stop(stopCtx)
+	}()
+
+	select {
+	case stopErr := <-done:
+		err = errors.Wrap(stopErr, "failed to stop component")
+		return err
+	case <-stopCtx.Done():
+		s.Logger().Warn("component exceeded its stop timeout; abandoning its Stop goroutine",
+			"component", fmt.Sprintf("%T", component))
+		err = errors.Wrapf(stopCtx.Err(), "%T exceeded its stop timeout", component)
+		return err
+	}
+}
+
+// waitUntilReady polls component's Ready method, if it implements HealthChecker, until it
+// reports nil or s.readyTimeout elapses. Components that don't implement HealthChecker are
+// considered ready as soon as Start returns.
+func (s *Service) waitUntilReady(ctx context.Context, component Component) error {
+	hc, ok := component.(HealthChecker)
 	if !ok {
-		// component doesn't depend on anything
 		return nil
 	}
 
-	for _, dependency := range dependencies {
-		// only add the dependency to the dependencies to return if it hasn't already been started
-		if _, ok := s.startedComponents[dependency]; !ok {
-			components = append(components, dependency)
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, s.readyTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(s.readyPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := hc.Ready(ctx); err == nil {
+			s.Logger().Info("dependency became ready",
+				"dependency", fmt.Sprintf("%T", component),
+				"duration_ms", time.Since(start).Milliseconds())
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			err := errors.Wrapf(ctx.Err(), "timed out waiting for %T to become ready", component)
+			s.Logger().Error("timed out waiting for dependency to become ready",
+				"dependency", fmt.Sprintf("%T", component),
+				"duration_ms", time.Since(start).Milliseconds(),
+				"error", err)
+			return err
+		case <-ticker.C:
+		}
+	}
+}
+
+// recordStartedLayer appends the subset of layer that actually started successfully as the
+// next layer for Stop to tear down.
+func (s *Service) recordStartedLayer(layer []Component) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	started := make([]Component, 0, len(layer))
+	for _, component := range layer {
+		if s.started[component] {
+			started = append(started, component)
+		}
+	}
+
+	if len(started) > 0 {
+		s.startedLayers = append(s.startedLayers, started)
+	}
+}
+
+// AddComponent registers c with the service after NewService has already been called, with c
+// depending on each of deps, which must already be registered components. The new edges are
+// validated exactly as RegisterDependentComponents would validate them: adding c is rejected
+// if any dep isn't already registered, or if depending on it would introduce a cycle. If the
+// service is already Running, c is started immediately - after waiting for it to become ready,
+// the same as any other component - and appended as its own layer at the end of the stack that
+// Stop walks in reverse, so it will be stopped before the dependencies it was just added with.
+func (s *Service) AddComponent(c Component, deps ...Component) error {
+	s.mu.Lock()
+
+	registered := make(map[Component]bool, len(s.components))
+	for _, existing := range s.components {
+		registered[existing] = true
+	}
+
+	if registered[c] {
+		s.mu.Unlock()
+		return errors.Errorf("%T is already a registered component", c)
+	}
+
+	for _, dep := range deps {
+		if !registered[dep] {
+			s.mu.Unlock()
+			return errors.Errorf("%T is not a registered component", dep)
+		}
+	}
+
+	for _, dep := range deps {
+		if err := s.registerDependency(c, dep); err != nil {
+			s.mu.Unlock()
+			return err
+		}
+	}
+
+	s.components = append(s.components, c)
+	running := s.State() == StateRunning
+	s.mu.Unlock()
+
+	if l, ok := c.(interface{ SetLogger(*slog.Logger) }); ok {
+		l.SetLogger(s.Logger())
+	}
+
+	if !running {
+		return nil
+	}
+
+	if err := s.startComponent(context.Background(), c); err != nil {
+		return errors.Wrap(err, "failed to start added component")
+	}
+
+	s.mu.Lock()
+	s.startedLayers = append(s.startedLayers, []Component{c})
+	s.mu.Unlock()
+
+	return nil
+}
+
+// RemoveComponent unregisters c. It refuses to remove c while any other registered component
+// still depends on it. Otherwise, if c was started, it's stopped - using the same
+// WithComponentStopTimeout/WithForceKillSlowStops behaviour as a regular Stop - and spliced out
+// of the stack of started layers before being dropped from the dependency graph entirely.
+func (s *Service) RemoveComponent(c Component) error {
+	s.mu.Lock()
+	for dependent := range s.dependents[c] {
+		s.mu.Unlock()
+		return errors.Errorf("cannot remove %T: %T still depends on it", c, dependent)
+	}
+	wasStarted := s.started[c]
+	s.mu.Unlock()
+
+	if wasStarted {
+		if err := s.stopComponent(context.Background(), c); err != nil {
+			return errors.Wrap(err, "failed to stop removed component")
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.started, c)
+	s.removeFromStartedLayers(c)
+	s.components = removeComponent(s.components, c)
+
+	for dep := range s.dependencies[c] {
+		delete(s.dependents[dep], c)
+	}
+	delete(s.dependencies, c)
+	delete(s.dependents, c)
+
+	return nil
+}
+
+// removeFromStartedLayers drops c from whichever started layer it belongs to, discarding the
+// layer entirely if that empties it. Callers must hold s.mu.
+func (s *Service) removeFromStartedLayers(c Component) {
+	for i, layer := range s.startedLayers {
+		for j, existing := range layer {
+			if existing == c {
+				s.startedLayers[i] = append(layer[:j], layer[j+1:]...)
+				break
+			}
+		}
+	}
+
+	layers := s.startedLayers[:0]
+	for _, layer := range s.startedLayers {
+		if len(layer) > 0 {
+			layers = append(layers, layer)
 		}
 	}
+	s.startedLayers = layers
+}
 
-	return components
+// removeComponent returns components with target removed, preserving order.
+func removeComponent(components []Component, target Component) []Component {
+	out := components[:0]
+	for _, c := range components {
+		if c != target {
+			out = append(out, c)
+		}
+	}
+	return out
 }
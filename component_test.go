@@ -2,9 +2,16 @@ package framework
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,13 +31,49 @@ func TestNewService(t *testing.T) {
 	assert.True(t, b.started)
 	assert.Equal(t, 1, b.startCallCount)
 
-	// check that component a was started before b since there's no dependencies and a was the first in
-	// the slice of components the service received
+	// a and b have no dependencies so they're both in layer 0 and are started concurrently -
+	// there's no guaranteed ordering between them, just that both actually ran
+}
+
+func TestServiceStartsIndependentComponentsConcurrently(t *testing.T) {
+	a := &CompA{}
+	b := &CompB{}
+	c := &CompC{}
+
+	service := NewService([]Component{a, b, c})
+
+	// c depends on both a and b
+	err := service.RegisterDependentComponents(c, a)
+	require.NoError(t, err)
+	err = service.RegisterDependentComponents(c, b)
+	require.NoError(t, err)
+
+	start := time.Now()
+	err = service.Start(context.Background())
+	require.NoError(t, err)
 
-	// NOTE: Technically this shouldn't matter since the caller isn't specifying the order in which the components should be
-	// started, just the components to start. However for the sake of this POC, it proves that we aren't calling a dependent
-	// component first
-	require.True(t, a.startedAt.Before(b.startedAt))
+	// a and b are both in layer 0 (each Start sleeps 100ms) and c is in layer 1 (another
+	// 100ms). If a and b ran sequentially this would take at least 300ms in total, so well
+	// under that confirms they overlapped
+	require.Less(t, time.Since(start), 250*time.Millisecond)
+
+	// c depends on both a and b so it must start strictly after both of them
+	require.True(t, c.startedAt.After(a.startedAt))
+	require.True(t, c.startedAt.After(b.startedAt))
+}
+
+func TestServiceWithMaxConcurrencyLimitsParallelism(t *testing.T) {
+	a := &CompA{}
+	b := &CompB{}
+
+	service := NewService([]Component{a, b}, WithMaxConcurrency(1))
+
+	start := time.Now()
+	err := service.Start(context.Background())
+	require.NoError(t, err)
+
+	// with concurrency capped at 1, a and b (both in layer 0) must run one after another
+	require.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
 }
 
 func TestNewServiceWithDependents(t *testing.T) {
@@ -70,7 +113,51 @@ func TestNewServiceWithCyclicDependency(t *testing.T) {
 	// now try to configure b to depend on a
 	err = service.RegisterDependentComponents(b, a)
 	require.Error(t, err)
-	assert.Equal(t, dependencyCycleError, err)
+
+	var cycleErr *CycleError
+	require.ErrorAs(t, err, &cycleErr)
+	assert.Equal(t, []Component{b, a, b}, cycleErr.Path)
+}
+
+func TestNewServiceWithLongerCyclicDependency(t *testing.T) {
+	a := &CompA{}
+	b := &CompB{}
+	c := &CompC{}
+
+	service := NewService([]Component{a, b, c})
+
+	// a depends on b, b depends on c
+	err := service.RegisterDependentComponents(a, b)
+	require.NoError(t, err)
+
+	err = service.RegisterDependentComponents(b, c)
+	require.NoError(t, err)
+
+	// now try to close the cycle: c depends on a
+	err = service.RegisterDependentComponents(c, a)
+	require.Error(t, err)
+
+	var cycleErr *CycleError
+	require.ErrorAs(t, err, &cycleErr)
+	assert.Equal(t, []Component{c, a, b, c}, cycleErr.Path)
+}
+
+func TestServiceDependencyGraph(t *testing.T) {
+	a := &CompA{}
+	b := &CompB{}
+	c := &CompC{}
+
+	service := NewService([]Component{a, b, c})
+
+	err := service.RegisterDependentComponents(a, b)
+	require.NoError(t, err)
+
+	err = service.RegisterDependentComponents(b, c)
+	require.NoError(t, err)
+
+	// c has no dependencies so it's in layer 0, b depends only on c so it's in layer 1,
+	// and a depends only on b so it's in layer 2
+	assert.Equal(t, [][]Component{{c}, {b}, {a}}, service.DependencyGraph())
 }
 
 func TestNewServiceWithExistingDependency(t *testing.T) {
@@ -280,3 +367,314 @@ func (c *CompC) Stop(ctx context.Context) error {
 	c.stoppedAt = time.Now()
 	return nil
 }
+
+func TestServiceStartTwiceReturnsErrAlreadyStarted(t *testing.T) {
+	a := &CompA{}
+
+	service := NewService([]Component{a})
+
+	err := service.Start(context.Background())
+	require.NoError(t, err)
+	assert.True(t, service.IsRunning())
+
+	err = service.Start(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, ErrAlreadyStarted, err)
+}
+
+func TestServiceStopIsIdempotent(t *testing.T) {
+	a := &CompA{}
+
+	service := NewService([]Component{a})
+
+	err := service.Start(context.Background())
+	require.NoError(t, err)
+
+	service.Stop(context.Background())
+	service.Stop(context.Background())
+
+	// stop must only have actually run once, even though we called it twice
+	assert.Equal(t, 1, a.stopCallCount)
+	assert.Equal(t, StateTerminated, service.State())
+}
+
+func TestServiceWaitBlocksUntilStopped(t *testing.T) {
+	a := &CompA{}
+
+	service := NewService([]Component{a})
+
+	err := service.Start(context.Background())
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		service.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Wait returned before Stop was called")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	service.Stop(context.Background())
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Wait did not return after Stop completed")
+	}
+}
+
+// delayedReadyComponent starts like CompA (a 100ms Start) but only reports itself Ready once
+// readyAfter has passed, to exercise Service.Start's readiness polling.
+type delayedReadyComponent struct {
+	CompA
+	readyAfter time.Time
+}
+
+func (d *delayedReadyComponent) Ready(ctx context.Context) error {
+	if time.Now().Before(d.readyAfter) {
+		return fmt.Errorf("not ready yet")
+	}
+	return nil
+}
+
+func (d *delayedReadyComponent) Healthy(ctx context.Context) error {
+	return d.Ready(ctx)
+}
+
+func TestServiceStartWaitsForComponentToBeReady(t *testing.T) {
+	a := &delayedReadyComponent{readyAfter: time.Now().Add(150 * time.Millisecond)}
+	b := &CompB{}
+
+	service := NewService([]Component{a, b}, WithReadyPollInterval(10*time.Millisecond))
+
+	// b depends on a, so b must not start until a reports itself ready
+	err := service.RegisterDependentComponents(b, a)
+	require.NoError(t, err)
+
+	err = service.Start(context.Background())
+	require.NoError(t, err)
+
+	require.True(t, b.startedAt.After(a.readyAfter))
+}
+
+// failingStopComponent fails to stop cleanly, to exercise Service.Stop's error collection.
+type failingStopComponent struct {
+	CompA
+	stopErr error
+}
+
+func (f *failingStopComponent) Stop(ctx context.Context) error {
+	_ = f.CompA.Stop(ctx)
+	return f.stopErr
+}
+
+func TestServiceStopCollectsComponentErrors(t *testing.T) {
+	a := &failingStopComponent{stopErr: errors.New("boom")}
+	b := &CompB{}
+
+	service := NewService([]Component{a, b})
+
+	err := service.Start(context.Background())
+	require.NoError(t, err)
+
+	stopErr := service.Stop(context.Background())
+	require.Error(t, stopErr)
+
+	var merr *multierror.Error
+	require.ErrorAs(t, stopErr, &merr)
+	assert.Len(t, merr.Errors, 1)
+}
+
+// hangingStopComponent's Stop ignores ctx cancellation entirely, to exercise
+// WithForceKillSlowStops abandoning it rather than blocking forever.
+type hangingStopComponent struct {
+	CompA
+}
+
+func (h *hangingStopComponent) Stop(ctx context.Context) error {
+	time.Sleep(time.Second)
+	return nil
+}
+
+func TestServiceStopForceKillsSlowComponent(t *testing.T) {
+	a := &hangingStopComponent{}
+
+	service := NewService(
+		[]Component{a},
+		WithComponentStopTimeout(50*time.Millisecond),
+		WithForceKillSlowStops(),
+	)
+
+	err := service.Start(context.Background())
+	require.NoError(t, err)
+
+	start := time.Now()
+	stopErr := service.Stop(context.Background())
+	require.Error(t, stopErr)
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+// loggingComponent embeds *BaseComponent so it picks up the Logger/SetLogger promoted
+// methods, the same way NatsClient and NatsSubscription do.
+type loggingComponent struct {
+	*BaseComponent
+}
+
+func (c *loggingComponent) Start(ctx context.Context) error { return nil }
+func (c *loggingComponent) Stop(ctx context.Context) error  { return nil }
+
+func TestNewServiceWithLoggerPropagatesToComponents(t *testing.T) {
+	a := &loggingComponent{BaseComponent: NewBaseComponent()}
+	logger := slog.Default()
+
+	service := NewService([]Component{a}, WithLogger(logger))
+
+	assert.Same(t, logger, service.Logger())
+	assert.Same(t, logger, a.Logger())
+}
+
+func TestServiceAddComponentRejectsUnregisteredDependency(t *testing.T) {
+	a := &CompA{}
+	c := &CompC{}
+
+	service := NewService([]Component{a})
+
+	err := service.AddComponent(c, &CompB{})
+	require.Error(t, err)
+}
+
+func TestServiceAddComponentRejectsCycle(t *testing.T) {
+	a := &CompA{}
+	c := &CompC{}
+
+	service := NewService([]Component{a})
+	// Wire that a already depends on c before c itself has ever been added.
+	require.NoError(t, service.RegisterDependentComponents(a, c))
+
+	// Adding c with a dependency on a would close the loop c -> a -> c.
+	err := service.AddComponent(c, a)
+	var cycleErr *CycleError
+	assert.ErrorAs(t, err, &cycleErr)
+}
+
+func TestServiceAddComponentRejectsAlreadyRegisteredComponent(t *testing.T) {
+	a := &CompA{}
+	b := &CompB{}
+
+	service := NewService([]Component{a, b})
+	require.NoError(t, service.Start(context.Background()))
+
+	// a is already registered; re-adding it must not double-start or double-register it,
+	// e.g. after a caller retries AddComponent following a transient error.
+	err := service.AddComponent(a)
+	require.Error(t, err)
+	assert.Equal(t, 1, a.startCallCount)
+
+	require.NoError(t, service.Stop(context.Background()))
+	assert.Equal(t, 1, a.stopCallCount)
+}
+
+func TestServiceAddComponentBeforeStartDoesNotStartIt(t *testing.T) {
+	a := &CompA{}
+	c := &CompC{}
+
+	service := NewService([]Component{a})
+	require.NoError(t, service.AddComponent(c, a))
+
+	assert.False(t, c.started)
+
+	require.NoError(t, service.Start(context.Background()))
+	assert.True(t, c.started)
+}
+
+// TestServiceAddComponentWhileRunningStartsAfterItsDependenciesThenStopsFirst adds a third
+// component, depending on both already-running components, while the service is Running, and
+// checks it's started only once its dependencies are up, and stopped before them on Stop.
+func TestServiceAddComponentWhileRunningStartsAfterItsDependenciesThenStopsFirst(t *testing.T) {
+	a := &CompA{}
+	b := &CompB{}
+	c := &CompC{}
+
+	service := NewService([]Component{a, b})
+	require.NoError(t, service.Start(context.Background()))
+
+	require.NoError(t, service.AddComponent(c, a, b))
+	assert.True(t, c.started)
+	assert.True(t, c.startedAt.After(a.startedAt))
+	assert.True(t, c.startedAt.After(b.startedAt))
+
+	require.NoError(t, service.Stop(context.Background()))
+	assert.True(t, c.stoppedAt.Before(a.stoppedAt))
+	assert.True(t, c.stoppedAt.Before(b.stoppedAt))
+}
+
+func TestServiceRemoveComponentRefusesWhileDependentsRemain(t *testing.T) {
+	a := &CompA{}
+	b := &CompB{}
+
+	service := NewService([]Component{a, b})
+	require.NoError(t, service.RegisterDependentComponents(b, a))
+
+	err := service.RemoveComponent(a)
+	require.Error(t, err)
+}
+
+func TestServiceRemoveComponentStopsItAndExcludesItFromLaterStop(t *testing.T) {
+	a := &CompA{}
+	b := &CompB{}
+
+	service := NewService([]Component{a, b})
+	require.NoError(t, service.Start(context.Background()))
+
+	require.NoError(t, service.RemoveComponent(b))
+	assert.False(t, b.started)
+	assert.Equal(t, 1, b.stopCallCount)
+
+	require.NoError(t, service.Stop(context.Background()))
+	assert.Equal(t, 1, b.stopCallCount)
+	assert.False(t, a.started)
+}
+
+// TestServiceAddComponentConcurrentWithReadersIsRaceFree exercises AddComponent mutating
+// components/dependencies/dependents at the same time as DependencyGraph and a HealthEndpoint
+// handler read them - exactly the "hot-reload a running service with /readyz being polled"
+// scenario - under `go test -race`.
+func TestServiceAddComponentConcurrentWithReadersIsRaceFree(t *testing.T) {
+	a := &CompA{}
+	b := &CompB{}
+
+	service := NewService([]Component{a, b})
+	require.NoError(t, service.Start(context.Background()))
+	endpoint := NewHealthEndpoint(":0", service)
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			_ = service.AddComponent(&CompC{})
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			_ = service.DependencyGraph()
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			endpoint.handleReadyz(httptest.NewRecorder(), req)
+		}
+	}()
+
+	wg.Wait()
+}
@@ -32,7 +32,9 @@ func main() {
 
 	time.Sleep(time.Second * 10)
 
-	service.Stop(context.Background())
+	if err := service.Stop(context.Background()); err != nil {
+		log.Println(err)
+	}
 }
 
 func sendSomeMessages() {
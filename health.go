@@ -0,0 +1,143 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// HealthChecker is an optional capability a Component can implement to report its health
+// and readiness. A component that doesn't implement it is treated as healthy and ready as
+// soon as its Start returns.
+type HealthChecker interface {
+	// Healthy reports whether the component is currently functioning correctly. It's used
+	// for liveness: a non-nil error indicates the component should be restarted.
+	Healthy(ctx context.Context) error
+	// Ready reports whether the component is ready to serve traffic or be depended upon. A
+	// non-nil error keeps Service.Start from starting this component's dependents, and keeps
+	// HealthEndpoint's /readyz reporting unready.
+	Ready(ctx context.Context) error
+}
+
+// HealthEndpoint is a Component that serves /livez and /readyz over HTTP, aggregating the
+// Healthy/Ready results of every HealthChecker component in a Service's dependency graph. A
+// component is only reported ready once every component it transitively depends on is also
+// ready.
+type HealthEndpoint struct {
+	*BaseComponent
+
+	addr    string
+	service *Service
+	server  *http.Server
+}
+
+// NewHealthEndpoint creates a HealthEndpoint that will listen on addr and report on the
+// components registered with service.
+func NewHealthEndpoint(addr string, service *Service) *HealthEndpoint {
+	return &HealthEndpoint{
+		BaseComponent: NewBaseComponent(),
+		addr:          addr,
+		service:       service,
+	}
+}
+
+func (h *HealthEndpoint) Start(ctx context.Context) error {
+	if err := h.MarkStarting(); err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", h.addr)
+	if err != nil {
+		wrapped := errors.Wrap(err, "failed to bind health endpoint")
+		h.MarkTerminated(wrapped)
+		return wrapped
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livez", h.handleLivez)
+	mux.HandleFunc("/readyz", h.handleReadyz)
+	h.server = &http.Server{Handler: mux}
+
+	go func() {
+		if err := h.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			h.Logger().Error("health endpoint stopped serving", "error", err)
+		}
+	}()
+
+	h.MarkRunning()
+	return nil
+}
+
+func (h *HealthEndpoint) Stop(ctx context.Context) error {
+	if !h.MarkStopping() {
+		h.Wait()
+		return nil
+	}
+
+	err := h.server.Shutdown(ctx)
+	h.MarkTerminated(err)
+	return err
+}
+
+func (h *HealthEndpoint) handleLivez(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	components, _ := h.service.snapshotGraph()
+
+	for _, component := range components {
+		hc, ok := component.(HealthChecker)
+		if !ok {
+			continue
+		}
+
+		if err := hc.Healthy(ctx); err != nil {
+			http.Error(w, fmt.Sprintf("%T is unhealthy: %s", component, err), http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *HealthEndpoint) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	components, dependencies := h.service.snapshotGraph()
+
+	visited := make(map[Component]bool, len(components))
+	for _, component := range components {
+		if err := h.readyIncludingDependencies(ctx, component, dependencies, visited); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// readyIncludingDependencies reports an error unless component, and everything it
+// transitively depends on, is ready. dependencies is a snapshot from Service.snapshotGraph,
+// not the live graph, so this can run without holding the service's lock.
+func (h *HealthEndpoint) readyIncludingDependencies(ctx context.Context, component Component, dependencies map[Component]map[Component]struct{}, visited map[Component]bool) error {
+	if visited[component] {
+		return nil
+	}
+	visited[component] = true
+
+	if hc, ok := component.(HealthChecker); ok {
+		if err := hc.Ready(ctx); err != nil {
+			return errors.Wrapf(err, "%T is not ready", component)
+		}
+	}
+
+	for dependency := range dependencies[component] {
+		if err := h.readyIncludingDependencies(ctx, dependency, dependencies, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
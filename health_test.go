@@ -0,0 +1,70 @@
+package framework
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHealthChecker wraps a Component and adds a controllable HealthChecker implementation.
+type fakeHealthChecker struct {
+	Component
+	healthy error
+	ready   error
+}
+
+func (f *fakeHealthChecker) Healthy(ctx context.Context) error { return f.healthy }
+func (f *fakeHealthChecker) Ready(ctx context.Context) error   { return f.ready }
+
+func TestHealthEndpointReadyzRequiresDependenciesReady(t *testing.T) {
+	a := &fakeHealthChecker{Component: &CompA{}}
+	b := &fakeHealthChecker{Component: &CompB{}, ready: errors.New("not ready yet")}
+
+	service := NewService([]Component{a, b})
+	err := service.RegisterDependentComponents(a, b)
+	require.NoError(t, err)
+
+	endpoint := NewHealthEndpoint(":0", service)
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+	endpoint.handleReadyz(rec, req)
+
+	// a is itself ready, but depends on b which isn't, so the aggregate must report unready
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestHealthEndpointReadyzWhenEverythingReady(t *testing.T) {
+	a := &fakeHealthChecker{Component: &CompA{}}
+	b := &fakeHealthChecker{Component: &CompB{}}
+
+	service := NewService([]Component{a, b})
+	err := service.RegisterDependentComponents(a, b)
+	require.NoError(t, err)
+
+	endpoint := NewHealthEndpoint(":0", service)
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+	endpoint.handleReadyz(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHealthEndpointLivezReportsUnhealthyComponent(t *testing.T) {
+	a := &fakeHealthChecker{Component: &CompA{}, healthy: errors.New("connection dropped")}
+
+	service := NewService([]Component{a})
+	endpoint := NewHealthEndpoint(":0", service)
+
+	req := httptest.NewRequest(http.MethodGet, "/livez", nil)
+	rec := httptest.NewRecorder()
+	endpoint.handleLivez(rec, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
@@ -0,0 +1,156 @@
+package framework
+
+import (
+	"log/slog"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrAlreadyStarted is returned by Start when it is called more than once on the same
+// Service or component.
+var ErrAlreadyStarted = errors.New("already started")
+
+// State represents where a Service or component currently is in its lifecycle.
+type State int
+
+const (
+	// StateNew is the state before Start has been called.
+	StateNew State = iota
+	// StateStarting is the state while Start is in progress.
+	StateStarting
+	// StateRunning is the state once Start has completed successfully.
+	StateRunning
+	// StateStopping is the state while Stop is in progress.
+	StateStopping
+	// StateTerminated is the state once Stop has completed, or Start has failed.
+	StateTerminated
+)
+
+func (s State) String() string {
+	switch s {
+	case StateNew:
+		return "new"
+	case StateStarting:
+		return "starting"
+	case StateRunning:
+		return "running"
+	case StateStopping:
+		return "stopping"
+	case StateTerminated:
+		return "terminated"
+	default:
+		return "unknown"
+	}
+}
+
+// BaseComponent provides the lifecycle state machine (New -> Starting -> Running ->
+// Stopping -> Terminated) that Service, and any Component, is expected to honour. Embed it
+// to get State, IsRunning, Err and Wait for free, and drive the transitions from your own
+// Start/Stop using MarkStarting/MarkRunning/MarkStopping/MarkTerminated.
+type BaseComponent struct {
+	mu     sync.Mutex
+	state  State
+	err    error
+	done   chan struct{}
+	logger *slog.Logger
+}
+
+// NewBaseComponent creates a BaseComponent in the New state, ready to embed. Logger defaults
+// to slog.Default() until SetLogger is called - Service does this for every component it's
+// given as part of NewService.
+func NewBaseComponent() *BaseComponent {
+	return &BaseComponent{
+		done:   make(chan struct{}),
+		logger: slog.Default(),
+	}
+}
+
+// Logger returns the logger to use for this component's own log output.
+func (b *BaseComponent) Logger() *slog.Logger {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.logger
+}
+
+// SetLogger replaces the logger used for this component's log output.
+func (b *BaseComponent) SetLogger(logger *slog.Logger) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.logger = logger
+}
+
+// State returns the current lifecycle state.
+func (b *BaseComponent) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// IsRunning reports whether the component has finished starting and hasn't begun stopping.
+func (b *BaseComponent) IsRunning() bool {
+	return b.State() == StateRunning
+}
+
+// Err returns the error, if any, that caused the component to terminate.
+func (b *BaseComponent) Err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.err
+}
+
+// Wait blocks until the component reaches the Terminated state.
+func (b *BaseComponent) Wait() {
+	<-b.done
+}
+
+// MarkStarting transitions New -> Starting, returning ErrAlreadyStarted if Start has
+// already been called.
+func (b *BaseComponent) MarkStarting() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != StateNew {
+		return ErrAlreadyStarted
+	}
+
+	b.state = StateStarting
+	return nil
+}
+
+// MarkRunning transitions Starting -> Running.
+func (b *BaseComponent) MarkRunning() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = StateRunning
+}
+
+// MarkStopping transitions to Stopping and returns true, unless a shutdown is already in
+// progress or complete, in which case it returns false and the caller should call Wait
+// instead of stopping again.
+func (b *BaseComponent) MarkStopping() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateStopping || b.state == StateTerminated {
+		return false
+	}
+
+	b.state = StateStopping
+	return true
+}
+
+// MarkTerminated transitions to Terminated, recording err (which may be nil) and
+// unblocking any callers of Wait. It is safe to call more than once.
+func (b *BaseComponent) MarkTerminated(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateTerminated {
+		return
+	}
+
+	b.state = StateTerminated
+	b.err = err
+	close(b.done)
+}
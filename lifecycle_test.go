@@ -0,0 +1,67 @@
+package framework
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseComponentLifecycle(t *testing.T) {
+	b := NewBaseComponent()
+
+	assert.Equal(t, StateNew, b.State())
+	assert.False(t, b.IsRunning())
+
+	require.NoError(t, b.MarkStarting())
+	assert.Equal(t, StateStarting, b.State())
+	assert.False(t, b.IsRunning())
+
+	b.MarkRunning()
+	assert.Equal(t, StateRunning, b.State())
+	assert.True(t, b.IsRunning())
+
+	assert.True(t, b.MarkStopping())
+	assert.Equal(t, StateStopping, b.State())
+	assert.False(t, b.IsRunning())
+
+	done := make(chan struct{})
+	go func() {
+		b.Wait()
+		close(done)
+	}()
+
+	terminateErr := errors.New("boom")
+	b.MarkTerminated(terminateErr)
+
+	<-done
+	assert.Equal(t, StateTerminated, b.State())
+	assert.Equal(t, terminateErr, b.Err())
+}
+
+func TestBaseComponentMarkStartingTwiceReturnsErrAlreadyStarted(t *testing.T) {
+	b := NewBaseComponent()
+
+	require.NoError(t, b.MarkStarting())
+
+	err := b.MarkStarting()
+	require.Error(t, err)
+	assert.Equal(t, ErrAlreadyStarted, err)
+}
+
+func TestBaseComponentMarkStoppingIsIdempotent(t *testing.T) {
+	b := NewBaseComponent()
+	require.NoError(t, b.MarkStarting())
+	b.MarkRunning()
+
+	assert.True(t, b.MarkStopping())
+	// a second call to MarkStopping while a shutdown is already in progress should report
+	// that the caller needs to wait rather than starting a second shutdown
+	assert.False(t, b.MarkStopping())
+
+	b.MarkTerminated(nil)
+
+	// once terminated, MarkStopping should still report there's nothing further to do
+	assert.False(t, b.MarkStopping())
+}
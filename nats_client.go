@@ -2,13 +2,14 @@ package framework
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/nats-io/nats.go"
 	"github.com/pkg/errors"
 )
 
 type NatsClient struct {
+	*BaseComponent
+
 	serverURL string
 	client    *nats.Conn
 	jsContext nats.JetStreamContext
@@ -16,33 +17,72 @@ type NatsClient struct {
 
 func NewNatsClient(serverURL string) *NatsClient {
 	return &NatsClient{
-		serverURL: serverURL,
+		BaseComponent: NewBaseComponent(),
+		serverURL:     serverURL,
 	}
 }
 
 func (n *NatsClient) Start(ctx context.Context) error {
-	fmt.Println("nats client starting")
+	if err := n.MarkStarting(); err != nil {
+		return err
+	}
+
+	n.Logger().Info("nats client starting", "component", "NatsClient")
 
 	nc, err := nats.Connect(n.serverURL)
 	if err != nil {
-		return errors.Wrap(err, "failed to connect to NATs")
+		wrapped := errors.Wrap(err, "failed to connect to NATs")
+		n.MarkTerminated(wrapped)
+		return wrapped
 	}
 
 	n.client = nc
 
 	js, err := nc.JetStream()
 	if err != nil {
-		return errors.Wrap(err, "failed to setup Jetstream")
+		wrapped := errors.Wrap(err, "failed to setup Jetstream")
+		n.MarkTerminated(wrapped)
+		return wrapped
 	}
 	n.jsContext = js
 
+	n.MarkRunning()
 	return nil
 }
 
 func (n *NatsClient) Stop(ctx context.Context) error {
-	fmt.Println("nats client stopping")
+	if !n.MarkStopping() {
+		n.Wait()
+		return nil
+	}
+
+	n.Logger().Info("nats client stopping", "component", "NatsClient")
 
 	n.client.Close()
 
+	n.MarkTerminated(nil)
+	return nil
+}
+
+// Healthy implements HealthChecker by checking the connection is still up.
+func (n *NatsClient) Healthy(ctx context.Context) error {
+	if n.client == nil || n.client.Status() != nats.CONNECTED {
+		return errors.New("nats client is not connected")
+	}
+
+	return nil
+}
+
+// Ready implements HealthChecker. The client is only ready once it's connected and
+// JetStream is actually responding, not just once Connect has returned.
+func (n *NatsClient) Ready(ctx context.Context) error {
+	if n.client == nil || n.client.Status() != nats.CONNECTED {
+		return errors.New("nats client is not connected")
+	}
+
+	if _, err := n.jsContext.AccountInfo(); err != nil {
+		return errors.Wrap(err, "jetstream is not responding")
+	}
+
 	return nil
 }
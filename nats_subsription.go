@@ -2,8 +2,9 @@ package framework
 
 import (
 	"context"
-	"fmt"
+	"log/slog"
 	"strings"
+	"sync"
 
 	"github.com/nats-io/nats.go"
 	"github.com/pkg/errors"
@@ -15,34 +16,78 @@ const (
 )
 
 type NatsSubscription struct {
+	*BaseComponent
+
 	natsClient *NatsClient
+
+	mu           sync.Mutex
+	subscription *nats.Subscription
 }
 
 func NewNatsSubscription(client *NatsClient) *NatsSubscription {
 	return &NatsSubscription{
-		natsClient: client,
+		BaseComponent: NewBaseComponent(),
+		natsClient:    client,
 	}
 }
 
 func (n *NatsSubscription) Start(ctx context.Context) error {
-	fmt.Println("nats sub starting")
+	if err := n.MarkStarting(); err != nil {
+		return err
+	}
 
-	err := createStream(n.natsClient.jsContext)
-	if err != nil {
+	n.Logger().Info("nats sub starting", "component", "NatsSubscription")
+
+	if err := createStream(n.natsClient.jsContext, n.Logger()); err != nil {
+		n.MarkTerminated(err)
 		return err
 	}
 
-	go subscriber(n.natsClient.jsContext)
+	sub, err := n.natsClient.jsContext.Subscribe(subject, n.handleMessage, nats.DeliverNew())
+	if err != nil {
+		wrapped := errors.Wrap(err, "failed to subscribe")
+		n.MarkTerminated(wrapped)
+		return wrapped
+	}
+
+	n.mu.Lock()
+	n.subscription = sub
+	n.mu.Unlock()
 
+	n.MarkRunning()
 	return nil
 }
 
 func (n *NatsSubscription) Stop(ctx context.Context) error {
-	fmt.Println("nats sub stopping")
+	if !n.MarkStopping() {
+		n.Wait()
+		return nil
+	}
+
+	n.Logger().Info("nats sub stopping", "component", "NatsSubscription")
+	n.MarkTerminated(nil)
 	return nil
 }
 
-func createStream(js nats.JetStreamContext) error {
+// Healthy implements HealthChecker by checking the subscription is still active.
+func (n *NatsSubscription) Healthy(ctx context.Context) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.subscription == nil || !n.subscription.IsValid() {
+		return errors.New("nats subscription is not active")
+	}
+
+	return nil
+}
+
+// Ready implements HealthChecker. The subscription is only ready once createStream has
+// completed and js.Subscribe has actually handed back an active subscription.
+func (n *NatsSubscription) Ready(ctx context.Context) error {
+	return n.Healthy(ctx)
+}
+
+func createStream(js nats.JetStreamContext, logger *slog.Logger) error {
 	stream, err := js.StreamInfo(streamName)
 	if err != nil && !strings.Contains(err.Error(), "stream not found") {
 		return errors.Wrap(err, "failed to check if stream existed")
@@ -50,11 +95,11 @@ func createStream(js nats.JetStreamContext) error {
 
 	// stream exists already
 	if stream != nil {
-		fmt.Println("stream already exists")
+		logger.Info("stream already exists", "stream", streamName)
 		return nil
 	}
 
-	fmt.Println("stream doesn't exist so creating")
+	logger.Info("stream doesn't exist so creating", "stream", streamName)
 
 	_, err = js.AddStream(&nats.StreamConfig{
 		Name:     "test",
@@ -67,17 +112,10 @@ func createStream(js nats.JetStreamContext) error {
 	return nil
 }
 
-func subscriber(js nats.JetStreamContext) {
-	_, err := js.Subscribe(subject, func(msg *nats.Msg) {
-		err := msg.Ack()
-		if err != nil {
-			fmt.Printf("failed to ack message: %s\n", err)
-			return
-		}
-		fmt.Printf("message received: %s\n", msg.Data)
-	}, nats.DeliverNew())
-
-	if err != nil {
-		fmt.Printf("failed to subscribe: %s\n", err)
+func (n *NatsSubscription) handleMessage(msg *nats.Msg) {
+	if err := msg.Ack(); err != nil {
+		n.Logger().Error("failed to ack message", "error", err)
+		return
 	}
+	n.Logger().Info("message received", "data", string(msg.Data))
 }